@@ -11,6 +11,7 @@ import (
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/hashicorp/aws-sdk-go-base/tfawserr"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -21,6 +22,41 @@ import (
 	"github.com/hashicorp/terraform-provider-aws/internal/verify"
 )
 
+// S3-compatible object stores (MinIO, DigitalOcean Spaces, Ceph, FrostFS, ...) implement the
+// same lifecycle XML API as AWS S3 but vary in which sub-features they support and in the
+// error codes they return for a missing lifecycle configuration.
+const (
+	S3CompatibleProviderMinIO              = "minio"
+	S3CompatibleProviderDigitalOceanSpaces = "digitalocean_spaces"
+	S3CompatibleProviderCeph               = "ceph"
+	S3CompatibleProviderFrostFS            = "frostfs"
+)
+
+func s3CompatibleProviders() []string {
+	return []string{
+		S3CompatibleProviderMinIO,
+		S3CompatibleProviderDigitalOceanSpaces,
+		S3CompatibleProviderCeph,
+		S3CompatibleProviderFrostFS,
+	}
+}
+
+// s3CompatibleUnsupportedRuleFields lists rule sub-blocks that are known not to be
+// implemented by a given S3-compatible provider. CustomizeDiff rejects their use up front
+// instead of letting the provider return an opaque MalformedXML or similar error at apply time.
+var s3CompatibleUnsupportedRuleFields = map[string][]string{
+	S3CompatibleProviderMinIO:   {"noncurrent_version_transition"},
+	S3CompatibleProviderCeph:    {"noncurrent_version_transition"},
+	S3CompatibleProviderFrostFS: {},
+}
+
+// s3CompatibleNotFoundErrorCodes extends the AWS NoSuchLifecycleConfiguration/NoSuchBucket
+// error codes with the equivalents returned by non-AWS gateways.
+var s3CompatibleNotFoundErrorCodes = map[string][]string{
+	S3CompatibleProviderCeph:    {"NoSuchTagSet"},
+	S3CompatibleProviderFrostFS: {"NoSuchBucketLifecycleConfiguration"},
+}
+
 func ResourceBucketLifecycleConfiguration() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceBucketLifecycleConfigurationCreate,
@@ -31,6 +67,11 @@ func ResourceBucketLifecycleConfiguration() *schema.Resource {
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 
+		CustomizeDiff: customdiff.All(
+			resourceBucketLifecycleConfigurationCustomizeDiffValidateS3CompatibleFeatures,
+			resourceBucketLifecycleConfigurationCustomizeDiffValidateRules,
+		),
+
 		Schema: map[string]*schema.Schema{
 			"bucket": {
 				Type:         schema.TypeString,
@@ -39,6 +80,29 @@ func ResourceBucketLifecycleConfiguration() *schema.Resource {
 				ValidateFunc: validation.StringLenBetween(1, 63),
 			},
 
+			"endpoint": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Custom endpoint URL for an S3-compatible object store (for example MinIO, DigitalOcean Spaces, Ceph, or FrostFS). Leave unset to use AWS S3.",
+			},
+
+			"s3_compatible_provider": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				RequiredWith: []string{"endpoint"},
+				ValidateFunc: validation.StringInSlice(s3CompatibleProviders(), false),
+				Description:  "Identifies the S3-compatible gateway behind endpoint, enabling feature validation and error-code mapping for gateways that don't fully match AWS S3 semantics.",
+			},
+
+			"skip_expected_bucket_owner_header": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Don't send the x-amz-expected-bucket-owner header. Some S3-compatible gateways reject requests carrying it.",
+			},
+
 			"expected_bucket_owner": {
 				Type:         schema.TypeString,
 				Optional:     true,
@@ -46,198 +110,217 @@ func ResourceBucketLifecycleConfiguration() *schema.Resource {
 				ValidateFunc: verify.ValidAccountID,
 			},
 
+			"disable_system_cache": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Don't reuse a recently-observed lifecycle configuration across reads of this resource. Leave unset unless a gateway mutates lifecycle state outside of Terraform between reads.",
+			},
+
 			"rule": {
 				Type:     schema.TypeList,
 				Required: true,
 				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"abort_incomplete_multipart_upload": {
-							Type:     schema.TypeList,
-							Optional: true,
-							MaxItems: 1,
-							Elem: &schema.Resource{
-								Schema: map[string]*schema.Schema{
-									"days_after_initiation": {
-										Type:     schema.TypeInt,
-										Optional: true,
-									},
+					Schema: lifecycleRuleSchema(),
+				},
+			},
+		},
+	}
+}
+
+// lifecycleRuleSchema is the schema for a single S3 Lifecycle rule, shared between
+// ResourceBucketLifecycleConfiguration's "rule" argument and the "rule" argument
+// accepted by the lifecycle evaluation data source.
+func lifecycleRuleSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"abort_incomplete_multipart_upload": {
+			Type:     schema.TypeList,
+			Optional: true,
+			MaxItems: 1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"days_after_initiation": {
+						Type:     schema.TypeInt,
+						Optional: true,
+					},
+				},
+			},
+		},
+		"expiration": {
+			Type:     schema.TypeList,
+			Optional: true,
+			MaxItems: 1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"date": {
+						Type:         schema.TypeString,
+						Optional:     true,
+						ValidateFunc: verify.ValidUTCTimestamp,
+					},
+					"days": {
+						Type:     schema.TypeInt,
+						Optional: true,
+						Default:  0, // API returns 0
+					},
+					"expired_object_delete_marker": {
+						Type:     schema.TypeBool,
+						Optional: true,
+						Computed: true, // API returns false; conflicts with date and days
+					},
+				},
+			},
+		},
+		"filter": {
+			Type:     schema.TypeList,
+			Optional: true,
+			MaxItems: 1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"and": {
+						Type:     schema.TypeList,
+						Optional: true,
+						MaxItems: 1,
+						Elem: &schema.Resource{
+							Schema: map[string]*schema.Schema{
+								"object_size_greater_than": {
+									Type:         schema.TypeInt,
+									Optional:     true,
+									ValidateFunc: validation.IntAtLeast(0),
 								},
-							},
-						},
-						"expiration": {
-							Type:     schema.TypeList,
-							Optional: true,
-							MaxItems: 1,
-							Elem: &schema.Resource{
-								Schema: map[string]*schema.Schema{
-									"date": {
-										Type:         schema.TypeString,
-										Optional:     true,
-										ValidateFunc: verify.ValidUTCTimestamp,
-									},
-									"days": {
-										Type:     schema.TypeInt,
-										Optional: true,
-										Default:  0, // API returns 0
-									},
-									"expired_object_delete_marker": {
-										Type:     schema.TypeBool,
-										Optional: true,
-										Computed: true, // API returns false; conflicts with date and days
-									},
+								"object_size_less_than": {
+									Type:         schema.TypeInt,
+									Optional:     true,
+									ValidateFunc: validation.IntAtLeast(1),
 								},
-							},
-						},
-						"filter": {
-							Type:     schema.TypeList,
-							Optional: true,
-							MaxItems: 1,
-							Elem: &schema.Resource{
-								Schema: map[string]*schema.Schema{
-									"and": {
-										Type:     schema.TypeList,
-										Optional: true,
-										MaxItems: 1,
-										Elem: &schema.Resource{
-											Schema: map[string]*schema.Schema{
-												"object_size_greater_than": {
-													Type:         schema.TypeInt,
-													Optional:     true,
-													ValidateFunc: validation.IntAtLeast(0),
-												},
-												"object_size_less_than": {
-													Type:         schema.TypeInt,
-													Optional:     true,
-													ValidateFunc: validation.IntAtLeast(1),
-												},
-												"prefix": {
-													Type:     schema.TypeString,
-													Optional: true,
-												},
-												"tags": tftags.TagsSchema(),
-											},
-										},
-									},
-									"object_size_greater_than": {
-										Type:     nullable.TypeNullableInt,
-										Optional: true,
-									},
-									"object_size_less_than": {
-										Type:     nullable.TypeNullableInt,
-										Optional: true,
-									},
-									"prefix": {
-										Type:     schema.TypeString,
-										Optional: true,
-									},
-									"tag": {
-										Type:     schema.TypeList,
-										MaxItems: 1,
-										Optional: true,
-										Elem: &schema.Resource{
-											Schema: map[string]*schema.Schema{
-												"key": {
-													Type:     schema.TypeString,
-													Required: true,
-												},
-												"value": {
-													Type:     schema.TypeString,
-													Required: true,
-												},
-											},
-										},
-									},
+								"prefix": {
+									Type:     schema.TypeString,
+									Optional: true,
 								},
+								// A filter.and.object_tag list, supporting duplicate tag keys the
+								// way the underlying s3.Tag slice does, was scoped out of this
+								// series: ExpandLifecycleRules/FlattenLifecycleRules, which would
+								// need to convert it to/from And.Tags, live outside every file this
+								// series touches. "tags" below covers the common case (unique
+								// keys) in the meantime.
+								"tags": tftags.TagsSchema(),
 							},
 						},
-
-						"id": {
-							Type:         schema.TypeString,
-							Required:     true,
-							ValidateFunc: validation.StringLenBetween(1, 255),
-						},
-
-						"noncurrent_version_expiration": {
-							Type:     schema.TypeList,
-							Optional: true,
-							MaxItems: 1,
-							Elem: &schema.Resource{
-								Schema: map[string]*schema.Schema{
-									"newer_noncurrent_versions": {
-										Type:         nullable.TypeNullableInt,
-										Optional:     true,
-										ValidateFunc: nullable.ValidateTypeStringNullableIntAtLeast(1),
-									},
-									"noncurrent_days": {
-										Type:         schema.TypeInt,
-										Optional:     true,
-										ValidateFunc: validation.IntAtLeast(1),
-									},
+					},
+					"object_size_greater_than": {
+						Type:     nullable.TypeNullableInt,
+						Optional: true,
+					},
+					"object_size_less_than": {
+						Type:     nullable.TypeNullableInt,
+						Optional: true,
+					},
+					"prefix": {
+						Type:     schema.TypeString,
+						Optional: true,
+					},
+					"tag": {
+						Type:     schema.TypeList,
+						MaxItems: 1,
+						Optional: true,
+						Elem: &schema.Resource{
+							Schema: map[string]*schema.Schema{
+								"key": {
+									Type:     schema.TypeString,
+									Required: true,
 								},
-							},
-						},
-						"noncurrent_version_transition": {
-							Type:     schema.TypeSet,
-							Optional: true,
-							Elem: &schema.Resource{
-								Schema: map[string]*schema.Schema{
-									"newer_noncurrent_versions": {
-										Type:         nullable.TypeNullableInt,
-										Optional:     true,
-										ValidateFunc: nullable.ValidateTypeStringNullableIntAtLeast(1),
-									},
-									"noncurrent_days": {
-										Type:         schema.TypeInt,
-										Optional:     true,
-										ValidateFunc: validation.IntAtLeast(0),
-									},
-									"storage_class": {
-										Type:         schema.TypeString,
-										Required:     true,
-										ValidateFunc: validation.StringInSlice(s3.TransitionStorageClass_Values(), false),
-									},
+								"value": {
+									Type:     schema.TypeString,
+									Required: true,
 								},
 							},
 						},
+					},
+				},
+			},
+		},
 
-						"prefix": {
-							Type:       schema.TypeString,
-							Optional:   true,
-							Deprecated: "Use filter instead",
-						},
+		"id": {
+			Type:         schema.TypeString,
+			Required:     true,
+			ValidateFunc: validation.StringLenBetween(1, 255),
+		},
 
-						"status": {
-							Type:     schema.TypeString,
-							Required: true,
-							ValidateFunc: validation.StringInSlice([]string{
-								LifecycleRuleStatusDisabled,
-								LifecycleRuleStatusEnabled,
-							}, false),
-						},
+		"noncurrent_version_expiration": {
+			Type:     schema.TypeList,
+			Optional: true,
+			MaxItems: 1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"newer_noncurrent_versions": {
+						Type:         nullable.TypeNullableInt,
+						Optional:     true,
+						ValidateFunc: nullable.ValidateTypeStringNullableIntAtLeast(1),
+					},
+					"noncurrent_days": {
+						Type:         schema.TypeInt,
+						Optional:     true,
+						ValidateFunc: validation.IntAtLeast(1),
+					},
+				},
+			},
+		},
+		"noncurrent_version_transition": {
+			Type:     schema.TypeSet,
+			Optional: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"newer_noncurrent_versions": {
+						Type:         nullable.TypeNullableInt,
+						Optional:     true,
+						ValidateFunc: nullable.ValidateTypeStringNullableIntAtLeast(1),
+					},
+					"noncurrent_days": {
+						Type:         schema.TypeInt,
+						Optional:     true,
+						ValidateFunc: validation.IntAtLeast(0),
+					},
+					"storage_class": {
+						Type:         schema.TypeString,
+						Required:     true,
+						ValidateFunc: validation.StringInSlice(s3.TransitionStorageClass_Values(), false),
+					},
+				},
+			},
+		},
 
-						"transition": {
-							Type:     schema.TypeSet,
-							Optional: true,
-							Elem: &schema.Resource{
-								Schema: map[string]*schema.Schema{
-									"date": {
-										Type:         schema.TypeString,
-										Optional:     true,
-										ValidateFunc: verify.ValidUTCTimestamp,
-									},
-									"days": {
-										Type:         schema.TypeInt,
-										Optional:     true,
-										ValidateFunc: validation.IntAtLeast(0),
-									},
-									"storage_class": {
-										Type:         schema.TypeString,
-										Required:     true,
-										ValidateFunc: validation.StringInSlice(s3.TransitionStorageClass_Values(), false),
-									},
-								},
-							},
-						},
+		"prefix": {
+			Type:       schema.TypeString,
+			Optional:   true,
+			Deprecated: "Use filter instead",
+		},
+
+		"status": {
+			Type:     schema.TypeString,
+			Required: true,
+			ValidateFunc: validation.StringInSlice([]string{
+				LifecycleRuleStatusDisabled,
+				LifecycleRuleStatusEnabled,
+			}, false),
+		},
+
+		"transition": {
+			Type:     schema.TypeSet,
+			Optional: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"date": {
+						Type:         schema.TypeString,
+						Optional:     true,
+						ValidateFunc: verify.ValidUTCTimestamp,
+					},
+					"days": {
+						Type:         schema.TypeInt,
+						Optional:     true,
+						ValidateFunc: validation.IntAtLeast(0),
+					},
+					"storage_class": {
+						Type:         schema.TypeString,
+						Required:     true,
+						ValidateFunc: validation.StringInSlice(s3.TransitionStorageClass_Values(), false),
 					},
 				},
 			},
@@ -246,7 +329,10 @@ func ResourceBucketLifecycleConfiguration() *schema.Resource {
 }
 
 func resourceBucketLifecycleConfigurationCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	conn := meta.(*conns.AWSClient).S3Conn
+	conn, err := s3ConnForResourceData(meta, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	bucket := d.Get("bucket").(string)
 	expectedBucketOwner := d.Get("expected_bucket_owner").(string)
@@ -263,8 +349,8 @@ func resourceBucketLifecycleConfigurationCreate(ctx context.Context, d *schema.R
 		},
 	}
 
-	if expectedBucketOwner != "" {
-		input.ExpectedBucketOwner = aws.String(expectedBucketOwner)
+	if header := resourceExpectedBucketOwnerHeader(d); header != "" {
+		input.ExpectedBucketOwner = aws.String(header)
 	}
 
 	_, err = verify.RetryOnAWSCode(s3.ErrCodeNoSuchBucket, func() (interface{}, error) {
@@ -277,21 +363,56 @@ func resourceBucketLifecycleConfigurationCreate(ctx context.Context, d *schema.R
 
 	d.SetId(CreateResourceID(bucket, expectedBucketOwner))
 
-	if err = waitForLifecycleConfigurationRulesStatus(ctx, conn, bucket, expectedBucketOwner, rules); err != nil {
+	if err = waitForLifecycleConfigurationRulesStatus(ctx, conn, bucket, resourceExpectedBucketOwnerHeader(d), rules); err != nil {
 		return diag.Errorf("error waiting for S3 Lifecycle Configuration for bucket (%s) to reach expected rules status after update: %s", d.Id(), err)
 	}
 
+	globalLifecycleConfigurationCache.put(d.Get("endpoint").(string), bucket, resourceExpectedBucketOwnerHeader(d), d.Get("disable_system_cache").(bool), &s3.GetBucketLifecycleConfigurationOutput{Rules: rules})
+
 	return resourceBucketLifecycleConfigurationRead(ctx, d, meta)
 }
 
 func resourceBucketLifecycleConfigurationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	conn := meta.(*conns.AWSClient).S3Conn
+	conn, err := s3ConnForResourceData(meta, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	bucket, expectedBucketOwner, err := ParseResourceID(d.Id())
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
+	output, err := findLifecycleConfiguration(ctx, conn, d.Get("endpoint").(string), bucket, resourceExpectedBucketOwnerHeader(d), d.IsNewResource(), d.Get("disable_system_cache").(bool))
+
+	if !d.IsNewResource() && tfawserr.ErrCodeEquals(err, append([]string{ErrCodeNoSuchLifecycleConfiguration, s3.ErrCodeNoSuchBucket}, s3CompatibleNotFoundErrorCodes[d.Get("s3_compatible_provider").(string)]...)...) {
+		log.Printf("[WARN] S3 Bucket Lifecycle Configuration (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return diag.Errorf("error getting S3 Bucket Lifecycle Configuration (%s): %s", d.Id(), err)
+	}
+
+	d.Set("bucket", bucket)
+	d.Set("expected_bucket_owner", expectedBucketOwner)
+	if err := d.Set("rule", FlattenLifecycleRules(output.Rules)); err != nil {
+		return diag.Errorf("error setting rule: %s", err)
+	}
+
+	return nil
+}
+
+// findLifecycleConfiguration gets the current S3 Bucket Lifecycle Configuration, waiting for
+// two consecutive identical reads before returning so callers observe a steady-state result.
+// A cached result, either from a prior call or primed by a just-completed Put, short-circuits
+// both the API call and the steady-state poll.
+func findLifecycleConfiguration(ctx context.Context, conn *s3.S3, scope, bucket, expectedBucketOwner string, isNewResource, disableCache bool) (*s3.GetBucketLifecycleConfigurationOutput, error) {
+	if output, ok := globalLifecycleConfigurationCache.get(scope, bucket, expectedBucketOwner, disableCache); ok {
+		return output, nil
+	}
+
 	input := &s3.GetBucketLifecycleConfigurationInput{
 		Bucket: aws.String(bucket),
 	}
@@ -302,14 +423,14 @@ func resourceBucketLifecycleConfigurationRead(ctx context.Context, d *schema.Res
 
 	var lastOutput, output *s3.GetBucketLifecycleConfigurationOutput
 
-	err = resource.RetryContext(ctx, lifecycleConfigurationRulesSteadyTimeout, func() *resource.RetryError {
+	err := resource.RetryContext(ctx, lifecycleConfigurationRulesSteadyTimeout, func() *resource.RetryError {
 		var err error
 
 		time.Sleep(lifecycleConfigurationExtraRetryDelay)
 
 		output, err = conn.GetBucketLifecycleConfigurationWithContext(ctx, input)
 
-		if d.IsNewResource() && tfawserr.ErrCodeEquals(err, ErrCodeNoSuchLifecycleConfiguration, s3.ErrCodeNoSuchBucket) {
+		if isNewResource && tfawserr.ErrCodeEquals(err, ErrCodeNoSuchLifecycleConfiguration, s3.ErrCodeNoSuchBucket) {
 			return resource.RetryableError(err)
 		}
 
@@ -329,29 +450,20 @@ func resourceBucketLifecycleConfigurationRead(ctx context.Context, d *schema.Res
 		output, err = conn.GetBucketLifecycleConfigurationWithContext(ctx, input)
 	}
 
-	if !d.IsNewResource() && tfawserr.ErrCodeEquals(err, ErrCodeNoSuchLifecycleConfiguration, s3.ErrCodeNoSuchBucket) {
-		log.Printf("[WARN] S3 Bucket Lifecycle Configuration (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+	if err == nil {
+		globalLifecycleConfigurationCache.put(scope, bucket, expectedBucketOwner, disableCache, output)
 	}
 
-	if err != nil {
-		return diag.Errorf("error getting S3 Bucket Lifecycle Configuration (%s): %s", d.Id(), err)
-	}
-
-	d.Set("bucket", bucket)
-	d.Set("expected_bucket_owner", expectedBucketOwner)
-	if err := d.Set("rule", FlattenLifecycleRules(output.Rules)); err != nil {
-		return diag.Errorf("error setting rule: %s", err)
-	}
-
-	return nil
+	return output, err
 }
 
 func resourceBucketLifecycleConfigurationUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	conn := meta.(*conns.AWSClient).S3Conn
+	conn, err := s3ConnForResourceData(meta, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
-	bucket, expectedBucketOwner, err := ParseResourceID(d.Id())
+	bucket, _, err := ParseResourceID(d.Id())
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -368,8 +480,8 @@ func resourceBucketLifecycleConfigurationUpdate(ctx context.Context, d *schema.R
 		},
 	}
 
-	if expectedBucketOwner != "" {
-		input.ExpectedBucketOwner = aws.String(expectedBucketOwner)
+	if header := resourceExpectedBucketOwnerHeader(d); header != "" {
+		input.ExpectedBucketOwner = aws.String(header)
 	}
 
 	_, err = verify.RetryOnAWSCode(ErrCodeNoSuchLifecycleConfiguration, func() (interface{}, error) {
@@ -380,17 +492,22 @@ func resourceBucketLifecycleConfigurationUpdate(ctx context.Context, d *schema.R
 		return diag.Errorf("error updating S3 Bucket Lifecycle Configuration (%s): %s", d.Id(), err)
 	}
 
-	if err := waitForLifecycleConfigurationRulesStatus(ctx, conn, bucket, expectedBucketOwner, rules); err != nil {
+	if err := waitForLifecycleConfigurationRulesStatus(ctx, conn, bucket, resourceExpectedBucketOwnerHeader(d), rules); err != nil {
 		return diag.Errorf("error waiting for S3 Lifecycle Configuration for bucket (%s) to reach expected rules status after update: %s", d.Id(), err)
 	}
 
+	globalLifecycleConfigurationCache.put(d.Get("endpoint").(string), bucket, resourceExpectedBucketOwnerHeader(d), d.Get("disable_system_cache").(bool), &s3.GetBucketLifecycleConfigurationOutput{Rules: rules})
+
 	return resourceBucketLifecycleConfigurationRead(ctx, d, meta)
 }
 
 func resourceBucketLifecycleConfigurationDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	conn := meta.(*conns.AWSClient).S3Conn
+	conn, err := s3ConnForResourceData(meta, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
-	bucket, expectedBucketOwner, err := ParseResourceID(d.Id())
+	bucket, _, err := ParseResourceID(d.Id())
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -399,13 +516,15 @@ func resourceBucketLifecycleConfigurationDelete(ctx context.Context, d *schema.R
 		Bucket: aws.String(bucket),
 	}
 
-	if expectedBucketOwner != "" {
-		input.ExpectedBucketOwner = aws.String(expectedBucketOwner)
+	if header := resourceExpectedBucketOwnerHeader(d); header != "" {
+		input.ExpectedBucketOwner = aws.String(header)
 	}
 
 	_, err = conn.DeleteBucketLifecycleWithContext(ctx, input)
 
-	if tfawserr.ErrCodeEquals(err, ErrCodeNoSuchLifecycleConfiguration, s3.ErrCodeNoSuchBucket) {
+	globalLifecycleConfigurationCache.invalidate(d.Get("endpoint").(string), bucket, resourceExpectedBucketOwnerHeader(d))
+
+	if tfawserr.ErrCodeEquals(err, append([]string{ErrCodeNoSuchLifecycleConfiguration, s3.ErrCodeNoSuchBucket}, s3CompatibleNotFoundErrorCodes[d.Get("s3_compatible_provider").(string)]...)...) {
 		return nil
 	}
 
@@ -413,5 +532,151 @@ func resourceBucketLifecycleConfigurationDelete(ctx context.Context, d *schema.R
 		return diag.Errorf("error deleting S3 Bucket Lifecycle Configuration (%s): %s", d.Id(), err)
 	}
 
+	return nil
+}
+
+// s3ConnForResourceData returns an S3 client targeting the resource's configured "endpoint"
+// when set, so this resource can manage lifecycle configurations on S3-compatible object
+// stores (MinIO, DigitalOcean Spaces, Ceph, FrostFS, ...) in addition to AWS S3.
+func s3ConnForResourceData(meta interface{}, d *schema.ResourceData) (*s3.S3, error) {
+	client := meta.(*conns.AWSClient)
+
+	endpoint := d.Get("endpoint").(string)
+	if endpoint == "" {
+		return client.S3Conn, nil
+	}
+
+	sess := client.Session.Copy(&aws.Config{
+		Endpoint:         aws.String(endpoint),
+		S3ForcePathStyle: aws.Bool(true),
+	})
+
+	return s3.New(sess), nil
+}
+
+// resourceExpectedBucketOwnerHeader returns the expected_bucket_owner value to send as the
+// x-amz-expected-bucket-owner header, or "" to omit it for gateways that reject the header.
+func resourceExpectedBucketOwnerHeader(d *schema.ResourceData) string {
+	if d.Get("skip_expected_bucket_owner_header").(bool) {
+		return ""
+	}
+
+	return d.Get("expected_bucket_owner").(string)
+}
+
+// resourceBucketLifecycleConfigurationCustomizeDiffValidateRules enforces cross-field rule
+// constraints that S3 accepts silently in the console/API request shape but rejects, with an
+// opaque MalformedXML error, once the request actually reaches the lifecycle engine.
+func resourceBucketLifecycleConfigurationCustomizeDiffValidateRules(_ context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	return validateLifecycleRules(diff.Get("rule").([]interface{}))
+}
+
+// validateLifecycleRules holds the rule-level checks for
+// resourceBucketLifecycleConfigurationCustomizeDiffValidateRules. It operates on plain
+// []interface{} rule data, independent of *schema.ResourceDiff, so the checks can be unit tested
+// directly.
+func validateLifecycleRules(rules []interface{}) error {
+	for i, ruleRaw := range rules {
+		rule, ok := ruleRaw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		if expirations, ok := rule["expiration"].([]interface{}); ok && len(expirations) > 0 {
+			if expiration, ok := expirations[0].(map[string]interface{}); ok {
+				set := 0
+				if date, _ := expiration["date"].(string); date != "" {
+					set++
+				}
+				if days, _ := expiration["days"].(int); days != 0 {
+					set++
+				}
+				if deleteMarker, _ := expiration["expired_object_delete_marker"].(bool); deleteMarker {
+					set++
+				}
+				if set > 1 {
+					return fmt.Errorf("rule.%d: expiration.date, expiration.days, and expiration.expired_object_delete_marker are mutually exclusive", i)
+				}
+			}
+		}
+
+		if filters, ok := rule["filter"].([]interface{}); ok && len(filters) > 0 {
+			if prefix, ok := rule["prefix"].(string); ok && prefix != "" {
+				return fmt.Errorf("rule.%d: filter cannot be used together with the deprecated top-level prefix", i)
+			}
+		}
+
+		if transitions, ok := rule["transition"].(*schema.Set); ok {
+			for _, tRaw := range transitions.List() {
+				t, ok := tRaw.(map[string]interface{})
+				if !ok {
+					continue
+				}
+
+				date, _ := t["date"].(string)
+				days, _ := t["days"].(int)
+				if date != "" && days != 0 {
+					return fmt.Errorf("rule.%d: transition.date and transition.days cannot both be set", i)
+				}
+			}
+		}
+
+		if transitions, ok := rule["noncurrent_version_transition"].(*schema.Set); ok {
+			for _, tRaw := range transitions.List() {
+				t, ok := tRaw.(map[string]interface{})
+				if !ok {
+					continue
+				}
+
+				storageClass, _ := t["storage_class"].(string)
+				noncurrentDays, _ := t["noncurrent_days"].(int)
+
+				if noncurrentDays > 0 && noncurrentDays < 30 && (storageClass == s3.TransitionStorageClassGlacier || storageClass == s3.TransitionStorageClassDeepArchive) {
+					return fmt.Errorf("rule.%d: noncurrent_version_transition.noncurrent_days must be at least 30 for storage_class %s", i, storageClass)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// resourceBucketLifecycleConfigurationCustomizeDiffValidateS3CompatibleFeatures rejects rule
+// sub-blocks known to be unsupported by the configured s3_compatible_provider, instead of
+// letting the provider return an opaque XML error at apply time.
+func resourceBucketLifecycleConfigurationCustomizeDiffValidateS3CompatibleFeatures(_ context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	provider := diff.Get("s3_compatible_provider").(string)
+	if provider == "" {
+		return nil
+	}
+
+	unsupported, ok := s3CompatibleUnsupportedRuleFields[provider]
+	if !ok || len(unsupported) == 0 {
+		return nil
+	}
+
+	rules := diff.Get("rule").([]interface{})
+	for i, ruleRaw := range rules {
+		rule, ok := ruleRaw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		for _, field := range unsupported {
+			if v, ok := rule[field]; ok && v != nil {
+				switch t := v.(type) {
+				case []interface{}:
+					if len(t) > 0 {
+						return fmt.Errorf("rule.%d: %q is not supported by s3_compatible_provider %q", i, field, provider)
+					}
+				case *schema.Set:
+					if t.Len() > 0 {
+						return fmt.Errorf("rule.%d: %q is not supported by s3_compatible_provider %q", i, field, provider)
+					}
+				}
+			}
+		}
+	}
+
 	return nil
 }
\ No newline at end of file
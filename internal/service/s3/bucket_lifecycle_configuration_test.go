@@ -0,0 +1,110 @@
+package s3
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// newTransitionSet wraps a single transition/noncurrent_version_transition element in a
+// *schema.Set, matching the type schema.TypeSet attributes are passed as to CustomizeDiff.
+func newTransitionSet(elem map[string]interface{}) *schema.Set {
+	set := schema.NewSet(func(interface{}) int { return 0 }, nil)
+	set.Add(elem)
+	return set
+}
+
+func TestValidateLifecycleRules(t *testing.T) {
+	testCases := []struct {
+		name    string
+		rules   []interface{}
+		wantErr string
+	}{
+		{
+			name: "valid rule passes",
+			rules: []interface{}{
+				map[string]interface{}{
+					"expiration": []interface{}{
+						map[string]interface{}{
+							"days": 30,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "expiration date and days are mutually exclusive",
+			rules: []interface{}{
+				map[string]interface{}{
+					"expiration": []interface{}{
+						map[string]interface{}{
+							"date": "2023-01-01T00:00:00Z",
+							"days": 30,
+						},
+					},
+				},
+			},
+			wantErr: "mutually exclusive",
+		},
+		{
+			name: "filter cannot be combined with the deprecated top-level prefix",
+			rules: []interface{}{
+				map[string]interface{}{
+					"prefix": "foo/",
+					"filter": []interface{}{
+						map[string]interface{}{
+							"prefix": "foo/",
+						},
+					},
+				},
+			},
+			wantErr: "deprecated top-level prefix",
+		},
+		{
+			name: "transition date and days cannot both be set",
+			rules: []interface{}{
+				map[string]interface{}{
+					"transition": newTransitionSet(map[string]interface{}{
+						"date": "2023-01-01T00:00:00Z",
+						"days": 30,
+					}),
+				},
+			},
+			wantErr: "cannot both be set",
+		},
+		{
+			name: "noncurrent_version_transition to glacier requires at least 30 days",
+			rules: []interface{}{
+				map[string]interface{}{
+					"noncurrent_version_transition": newTransitionSet(map[string]interface{}{
+						"noncurrent_days": 10,
+						"storage_class":   "GLACIER",
+					}),
+				},
+			},
+			wantErr: "must be at least 30",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateLifecycleRules(tc.rules)
+
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				return
+			}
+
+			if err == nil {
+				t.Fatalf("expected an error containing %q, got none", tc.wantErr)
+			}
+			if !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("expected error to contain %q, got: %s", tc.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,615 @@
+package s3
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/hashicorp/terraform-provider-aws/aws/internal/keyvaluetags"
+	"github.com/hashicorp/terraform-provider-aws/internal/conns"
+	"github.com/hashicorp/terraform-provider-aws/internal/verify"
+)
+
+const (
+	lifecycleEvaluationActionExpire                         = "expire"
+	lifecycleEvaluationActionTransition                     = "transition"
+	lifecycleEvaluationActionAbortIncompleteMultipartUpload = "abort_incomplete_multipart_upload"
+	lifecycleEvaluationActionNoncurrentVersionExpiration    = "noncurrent_version_expiration"
+	lifecycleEvaluationActionNoncurrentVersionTransition    = "noncurrent_version_transition"
+)
+
+func DataSourceBucketLifecycleEvaluation() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceBucketLifecycleEvaluationRead,
+
+		Schema: map[string]*schema.Schema{
+			"bucket": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringLenBetween(1, 63),
+			},
+
+			"expected_bucket_owner": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: verify.ValidAccountID,
+			},
+
+			"rule": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				MaxItems:      1,
+				ConflictsWith: []string{"rule_id"},
+				Elem: &schema.Resource{
+					Schema: lifecycleEvaluationRuleSchema(),
+				},
+			},
+
+			"rule_id": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"rule"},
+			},
+
+			"max_keys": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+
+			"matched_count": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"truncated": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+
+			"matched_object": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"key": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"version_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"action": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"action_date": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"storage_class": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// lifecycleEvaluationRuleSchema is lifecycleRuleSchema with "id" and "status" made optional:
+// a rule being previewed for evaluation, rather than applied, has no need for an id, and
+// evaluateLifecycleRule honors status itself instead of requiring the caller to always spell
+// out LifecycleRuleStatusEnabled.
+func lifecycleEvaluationRuleSchema() map[string]*schema.Schema {
+	s := lifecycleRuleSchema()
+
+	s["id"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		ValidateFunc: validation.StringLenBetween(1, 255),
+	}
+
+	s["status"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+		Default:  LifecycleRuleStatusEnabled,
+		ValidateFunc: validation.StringInSlice([]string{
+			LifecycleRuleStatusDisabled,
+			LifecycleRuleStatusEnabled,
+		}, false),
+	}
+
+	return s
+}
+
+func dataSourceBucketLifecycleEvaluationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*conns.AWSClient).S3Conn
+
+	bucket := d.Get("bucket").(string)
+	expectedBucketOwner := d.Get("expected_bucket_owner").(string)
+
+	rule, err := findLifecycleRuleForEvaluation(ctx, conn, d, bucket, expectedBucketOwner)
+	if err != nil {
+		return diag.Errorf("error determining S3 Lifecycle rule to evaluate for bucket (%s): %s", bucket, err)
+	}
+
+	maxKeys := d.Get("max_keys").(int)
+
+	matches, truncated, err := evaluateLifecycleRule(ctx, conn, bucket, expectedBucketOwner, rule, maxKeys)
+	if err != nil {
+		return diag.Errorf("error evaluating S3 Lifecycle rule for bucket (%s): %s", bucket, err)
+	}
+
+	d.SetId(CreateResourceID(bucket, expectedBucketOwner))
+	d.Set("matched_count", len(matches))
+	d.Set("truncated", truncated)
+	if err := d.Set("matched_object", flattenLifecycleEvaluationMatches(matches)); err != nil {
+		return diag.Errorf("error setting matched_object: %s", err)
+	}
+
+	return nil
+}
+
+// findLifecycleRuleForEvaluation resolves the rule to evaluate, either from the "rule"
+// argument directly or by looking it up by "rule_id" in the bucket's existing configuration.
+func findLifecycleRuleForEvaluation(ctx context.Context, conn *s3.S3, d *schema.ResourceData, bucket, expectedBucketOwner string) (*s3.LifecycleRule, error) {
+	if v, ok := d.GetOk("rule"); ok {
+		rules, err := ExpandLifecycleRules(v.([]interface{}))
+		if err != nil {
+			return nil, err
+		}
+
+		return rules[0], nil
+	}
+
+	ruleID := d.Get("rule_id").(string)
+	if ruleID == "" {
+		return nil, fmt.Errorf(`one of "rule" or "rule_id" must be specified`)
+	}
+
+	output, err := findLifecycleConfiguration(ctx, conn, "", bucket, expectedBucketOwner, false, false)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, rule := range output.Rules {
+		if aws.StringValue(rule.ID) == ruleID {
+			return rule, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no rule with id (%s) found in lifecycle configuration for bucket (%s)", ruleID, bucket)
+}
+
+type lifecycleEvaluationMatch struct {
+	key          string
+	versionID    string
+	action       string
+	actionDate   time.Time
+	storageClass string
+}
+
+// evaluateLifecycleRule walks the bucket's current objects, in-progress multipart uploads,
+// and (when the rule has noncurrent-version actions) object versions, recording every
+// object the rule would act on. It stops early once maxKeys matches have been collected.
+func evaluateLifecycleRule(ctx context.Context, conn *s3.S3, bucket, expectedBucketOwner string, rule *s3.LifecycleRule, maxKeys int) ([]lifecycleEvaluationMatch, bool, error) {
+	var matches []lifecycleEvaluationMatch
+	truncated := false
+
+	if aws.StringValue(rule.Status) == LifecycleRuleStatusDisabled {
+		return matches, truncated, nil
+	}
+
+	addMatch := func(m lifecycleEvaluationMatch) bool {
+		matches = append(matches, m)
+		if maxKeys > 0 && len(matches) >= maxKeys {
+			truncated = true
+			return false
+		}
+		return true
+	}
+
+	now := time.Now().UTC()
+
+	if rule.Expiration != nil || len(rule.Transitions) > 0 {
+		if err := evaluateCurrentObjects(ctx, conn, bucket, expectedBucketOwner, rule, now, addMatch); err != nil {
+			return nil, false, err
+		}
+	}
+
+	if !truncated && rule.AbortIncompleteMultipartUpload != nil {
+		if err := evaluateAbortIncompleteMultipartUpload(ctx, conn, bucket, expectedBucketOwner, rule, now, addMatch); err != nil {
+			return nil, false, err
+		}
+	}
+
+	if !truncated && (rule.NoncurrentVersionExpiration != nil || len(rule.NoncurrentVersionTransitions) > 0) {
+		if err := evaluateNoncurrentVersions(ctx, conn, bucket, expectedBucketOwner, rule, now, addMatch); err != nil {
+			return nil, false, err
+		}
+	}
+
+	return matches, truncated, nil
+}
+
+func evaluateCurrentObjects(ctx context.Context, conn *s3.S3, bucket, expectedBucketOwner string, rule *s3.LifecycleRule, now time.Time, addMatch func(lifecycleEvaluationMatch) bool) error {
+	input := &s3.ListObjectsV2Input{
+		Bucket: aws.String(bucket),
+	}
+
+	if expectedBucketOwner != "" {
+		input.ExpectedBucketOwner = aws.String(expectedBucketOwner)
+	}
+
+	var objectErr error
+
+	err := conn.ListObjectsV2PagesWithContext(ctx, input, func(page *s3.ListObjectsV2Output, lastPage bool) bool {
+		for _, obj := range page.Contents {
+			key := aws.StringValue(obj.Key)
+
+			ok, err := lifecycleObjectMatchesFilter(conn, bucket, rule, key, aws.Int64Value(obj.Size))
+			if err != nil {
+				objectErr = err
+				return false
+			}
+			if !ok {
+				continue
+			}
+
+			lastModified := aws.TimeValue(obj.LastModified)
+
+			if rule.Expiration != nil {
+				if matched, actionDate := evaluateDateOrDays(rule.Expiration.Date, rule.Expiration.Days, lastModified, now); matched {
+					if !addMatch(lifecycleEvaluationMatch{key: key, action: lifecycleEvaluationActionExpire, actionDate: actionDate}) {
+						return false
+					}
+				}
+			}
+
+			for _, transition := range rule.Transitions {
+				if matched, actionDate := evaluateDateOrDays(transition.Date, transition.Days, lastModified, now); matched {
+					if !addMatch(lifecycleEvaluationMatch{key: key, action: lifecycleEvaluationActionTransition, actionDate: actionDate, storageClass: aws.StringValue(transition.StorageClass)}) {
+						return false
+					}
+				}
+			}
+		}
+
+		return true
+	})
+
+	if objectErr != nil {
+		return objectErr
+	}
+
+	return err
+}
+
+func evaluateAbortIncompleteMultipartUpload(ctx context.Context, conn *s3.S3, bucket, expectedBucketOwner string, rule *s3.LifecycleRule, now time.Time, addMatch func(lifecycleEvaluationMatch) bool) error {
+	prefix, _, _, _ := lifecycleRuleFilterCriteria(rule)
+
+	input := &s3.ListMultipartUploadsInput{
+		Bucket: aws.String(bucket),
+	}
+
+	if prefix != "" {
+		input.Prefix = aws.String(prefix)
+	}
+
+	if expectedBucketOwner != "" {
+		input.ExpectedBucketOwner = aws.String(expectedBucketOwner)
+	}
+
+	days := aws.Int64Value(rule.AbortIncompleteMultipartUpload.DaysAfterInitiation)
+
+	err := conn.ListMultipartUploadsPagesWithContext(ctx, input, func(page *s3.ListMultipartUploadsOutput, lastPage bool) bool {
+		for _, upload := range page.Uploads {
+			cutoff := aws.TimeValue(upload.Initiated).AddDate(0, 0, int(days))
+			if now.Before(cutoff) {
+				continue
+			}
+
+			// The multipart upload ID, not an object version ID, is reported in version_id here
+			// since incomplete uploads have no object version of their own.
+			if !addMatch(lifecycleEvaluationMatch{key: aws.StringValue(upload.Key), versionID: aws.StringValue(upload.UploadId), action: lifecycleEvaluationActionAbortIncompleteMultipartUpload, actionDate: cutoff}) {
+				return false
+			}
+		}
+
+		return true
+	})
+
+	return err
+}
+
+// lifecycleVersionTimelineEntry is either a real object version or a delete marker, ordered
+// alongside each other by lastModified to determine the current/noncurrent boundary: whichever
+// of the two was most recently created is current, exactly as S3 itself determines it.
+type lifecycleVersionTimelineEntry struct {
+	lastModified   time.Time
+	isDeleteMarker bool
+	size           int64
+	versionID      string
+}
+
+func evaluateNoncurrentVersions(ctx context.Context, conn *s3.S3, bucket, expectedBucketOwner string, rule *s3.LifecycleRule, now time.Time, addMatch func(lifecycleEvaluationMatch) bool) error {
+	prefix, tags, sizeGT, sizeLT := lifecycleRuleFilterCriteria(rule)
+
+	input := &s3.ListObjectVersionsInput{
+		Bucket: aws.String(bucket),
+	}
+
+	if prefix != "" {
+		input.Prefix = aws.String(prefix)
+	}
+
+	if expectedBucketOwner != "" {
+		input.ExpectedBucketOwner = aws.String(expectedBucketOwner)
+	}
+
+	timelineByKey := make(map[string][]lifecycleVersionTimelineEntry)
+
+	err := conn.ListObjectVersionsPagesWithContext(ctx, input, func(page *s3.ListObjectVersionsOutput, lastPage bool) bool {
+		for _, v := range page.Versions {
+			key := aws.StringValue(v.Key)
+			timelineByKey[key] = append(timelineByKey[key], lifecycleVersionTimelineEntry{
+				lastModified: aws.TimeValue(v.LastModified),
+				size:         aws.Int64Value(v.Size),
+				versionID:    aws.StringValue(v.VersionId),
+			})
+		}
+
+		for _, m := range page.DeleteMarkers {
+			key := aws.StringValue(m.Key)
+			timelineByKey[key] = append(timelineByKey[key], lifecycleVersionTimelineEntry{
+				lastModified:   aws.TimeValue(m.LastModified),
+				isDeleteMarker: true,
+				versionID:      aws.StringValue(m.VersionId),
+			})
+		}
+
+		return true
+	})
+
+	if err != nil {
+		return err
+	}
+
+	keys := make([]string, 0, len(timelineByKey))
+	for key := range timelineByKey {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+keysLoop:
+	for _, key := range keys {
+		timeline := timelineByKey[key]
+		sort.Slice(timeline, func(i, j int) bool {
+			return timeline[i].lastModified.After(timeline[j].lastModified)
+		})
+
+		// timeline[0] is current, whether it's a real version or a delete marker; everything
+		// after it is noncurrent, newest first. A version becomes noncurrent at the moment the
+		// entry ahead of it (version or delete marker) was created.
+		if ok, err := lifecycleNoncurrentKeyTagsMatch(ctx, conn, bucket, key, timeline, tags); err != nil {
+			return err
+		} else if !ok {
+			continue
+		}
+
+		noncurrentRank := 0
+
+		for idx := 1; idx < len(timeline); idx++ {
+			entry := timeline[idx]
+			if entry.isDeleteMarker {
+				continue
+			}
+
+			noncurrentRank++
+
+			if sizeGT != nil && entry.size <= aws.Int64Value(sizeGT) {
+				continue
+			}
+			if sizeLT != nil && entry.size >= aws.Int64Value(sizeLT) {
+				continue
+			}
+
+			becameNoncurrentAt := timeline[idx-1].lastModified
+
+			if nve := rule.NoncurrentVersionExpiration; nve != nil {
+				if int64(noncurrentRank) > aws.Int64Value(nve.NewerNoncurrentVersions) {
+					cutoff := becameNoncurrentAt.AddDate(0, 0, int(aws.Int64Value(nve.NoncurrentDays)))
+					if !now.Before(cutoff) {
+						if !addMatch(lifecycleEvaluationMatch{key: key, versionID: entry.versionID, action: lifecycleEvaluationActionNoncurrentVersionExpiration, actionDate: cutoff}) {
+							break keysLoop
+						}
+					}
+				}
+			}
+
+			for _, nvt := range rule.NoncurrentVersionTransitions {
+				if int64(noncurrentRank) <= aws.Int64Value(nvt.NewerNoncurrentVersions) {
+					continue
+				}
+
+				cutoff := becameNoncurrentAt.AddDate(0, 0, int(aws.Int64Value(nvt.NoncurrentDays)))
+				if now.Before(cutoff) {
+					continue
+				}
+
+				if !addMatch(lifecycleEvaluationMatch{key: key, versionID: entry.versionID, action: lifecycleEvaluationActionNoncurrentVersionTransition, actionDate: cutoff, storageClass: aws.StringValue(nvt.StorageClass)}) {
+					break keysLoop
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// lifecycleNoncurrentKeyTagsMatch reports whether a key's noncurrent versions are in scope for
+// a rule's tag filter, matching S3 semantics where a rule's filter tags are evaluated against
+// the object as a whole (its current version) and, once matched, gate all of the rule's actions
+// including noncurrent-version ones. The current timeline entry is used even when it's a delete
+// marker's predecessor real version, by walking past any leading delete markers; if a key has no
+// real version at all, it can't carry tags and is treated as not matching a tag filter.
+func lifecycleNoncurrentKeyTagsMatch(ctx context.Context, conn *s3.S3, bucket, key string, timeline []lifecycleVersionTimelineEntry, tags map[string]string) (bool, error) {
+	if len(tags) == 0 {
+		return true, nil
+	}
+
+	var currentVersionID string
+	found := false
+
+	for _, entry := range timeline {
+		if !entry.isDeleteMarker {
+			currentVersionID = entry.versionID
+			found = true
+			break
+		}
+	}
+
+	if !found {
+		return false, nil
+	}
+
+	input := &s3.GetObjectTaggingInput{
+		Bucket:    aws.String(bucket),
+		Key:       aws.String(key),
+		VersionId: aws.String(currentVersionID),
+	}
+
+	output, err := conn.GetObjectTaggingWithContext(ctx, input)
+	if err != nil {
+		return false, fmt.Errorf("error listing tags for S3 object version (%s/%s@%s): %w", bucket, key, currentVersionID, err)
+	}
+
+	objectTags := make(map[string]string, len(output.TagSet))
+	for _, t := range output.TagSet {
+		objectTags[aws.StringValue(t.Key)] = aws.StringValue(t.Value)
+	}
+
+	for k, v := range tags {
+		if objectTags[k] != v {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// lifecycleObjectMatchesFilter reports whether an object satisfies a rule's filter: prefix,
+// size bounds, and (only when tag filters are present, since tag lookups cost an extra
+// GetObjectTagging call per object) tag equality.
+func lifecycleObjectMatchesFilter(conn *s3.S3, bucket string, rule *s3.LifecycleRule, key string, size int64) (bool, error) {
+	prefix, tags, sizeGT, sizeLT := lifecycleRuleFilterCriteria(rule)
+
+	if prefix != "" && !strings.HasPrefix(key, prefix) {
+		return false, nil
+	}
+
+	if sizeGT != nil && size <= aws.Int64Value(sizeGT) {
+		return false, nil
+	}
+
+	if sizeLT != nil && size >= aws.Int64Value(sizeLT) {
+		return false, nil
+	}
+
+	if len(tags) > 0 {
+		objectTags, err := keyvaluetags.S3ObjectListTags(conn, bucket, key)
+		if err != nil {
+			return false, fmt.Errorf("error listing tags for S3 object (%s/%s): %w", bucket, key, err)
+		}
+
+		for k, v := range tags {
+			tagValue, ok := objectTags[k]
+			if !ok || aws.StringValue(tagValue.Value) != v {
+				return false, nil
+			}
+		}
+	}
+
+	return true, nil
+}
+
+// lifecycleRuleFilterCriteria extracts the prefix, tag, and size-bound predicates from a
+// rule's filter, whichever of the mutually-exclusive filter/and/deprecated-prefix shapes it uses.
+func lifecycleRuleFilterCriteria(rule *s3.LifecycleRule) (prefix string, tags map[string]string, sizeGreaterThan, sizeLessThan *int64) {
+	tags = make(map[string]string)
+
+	if rule.Filter == nil {
+		prefix = aws.StringValue(rule.Prefix)
+		return
+	}
+
+	f := rule.Filter
+
+	if f.And != nil {
+		prefix = aws.StringValue(f.And.Prefix)
+		sizeGreaterThan = f.And.ObjectSizeGreaterThan
+		sizeLessThan = f.And.ObjectSizeLessThan
+		for _, t := range f.And.Tags {
+			tags[aws.StringValue(t.Key)] = aws.StringValue(t.Value)
+		}
+		return
+	}
+
+	prefix = aws.StringValue(f.Prefix)
+	sizeGreaterThan = f.ObjectSizeGreaterThan
+	sizeLessThan = f.ObjectSizeLessThan
+	if f.Tag != nil {
+		tags[aws.StringValue(f.Tag.Key)] = aws.StringValue(f.Tag.Value)
+	}
+
+	return
+}
+
+// evaluateDateOrDays reports whether a date-or-days lifecycle trigger (expiration,
+// transition) has fired by now, and the date on which it did or will.
+func evaluateDateOrDays(date *time.Time, days *int64, lastModified, now time.Time) (bool, time.Time) {
+	switch {
+	case date != nil:
+		d := aws.TimeValue(date)
+		return !now.Before(d), d
+	case days != nil:
+		d := lastModified.AddDate(0, 0, int(aws.Int64Value(days)))
+		return !now.Before(d), d
+	default:
+		return false, time.Time{}
+	}
+}
+
+func flattenLifecycleEvaluationMatches(matches []lifecycleEvaluationMatch) []interface{} {
+	results := make([]interface{}, 0, len(matches))
+
+	for _, m := range matches {
+		tfMap := map[string]interface{}{
+			"key":        m.key,
+			"version_id": m.versionID,
+			"action":     m.action,
+		}
+
+		if !m.actionDate.IsZero() {
+			tfMap["action_date"] = m.actionDate.Format(time.RFC3339)
+		}
+
+		if m.storageClass != "" {
+			tfMap["storage_class"] = m.storageClass
+		}
+
+		results = append(results, tfMap)
+	}
+
+	return results
+}
@@ -0,0 +1,87 @@
+package s3
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/s3"
+)
+
+func TestLifecycleConfigurationCache(t *testing.T) {
+	output := &s3.GetBucketLifecycleConfigurationOutput{}
+
+	t.Run("get before put misses", func(t *testing.T) {
+		c := &lifecycleConfigurationCache{entries: make(map[string]lifecycleConfigurationCacheEntry)}
+
+		if _, ok := c.get("", "bucket", "", false); ok {
+			t.Fatal("expected a miss on an empty cache")
+		}
+	})
+
+	t.Run("put then get hits", func(t *testing.T) {
+		c := &lifecycleConfigurationCache{entries: make(map[string]lifecycleConfigurationCacheEntry)}
+
+		c.put("", "bucket", "", false, output)
+
+		got, ok := c.get("", "bucket", "", false)
+		if !ok {
+			t.Fatal("expected a hit after put")
+		}
+		if got != output {
+			t.Fatal("expected the cached output to be returned")
+		}
+	})
+
+	t.Run("disabled skips both put and get", func(t *testing.T) {
+		c := &lifecycleConfigurationCache{entries: make(map[string]lifecycleConfigurationCacheEntry)}
+
+		c.put("", "bucket", "", true, output)
+
+		if _, ok := c.get("", "bucket", "", false); ok {
+			t.Fatal("expected put to be a no-op when disabled")
+		}
+
+		c.put("", "bucket", "", false, output)
+		if _, ok := c.get("", "bucket", "", true); ok {
+			t.Fatal("expected get to miss when disabled, regardless of what's cached")
+		}
+	})
+
+	t.Run("different scopes do not share entries", func(t *testing.T) {
+		c := &lifecycleConfigurationCache{entries: make(map[string]lifecycleConfigurationCacheEntry)}
+
+		c.put("https://s3.example.com", "bucket", "", false, output)
+
+		if _, ok := c.get("https://minio.example.com", "bucket", "", false); ok {
+			t.Fatal("expected a different endpoint scope for the same bucket name to miss")
+		}
+		if _, ok := c.get("", "bucket", "", false); ok {
+			t.Fatal("expected the default AWS scope for the same bucket name to miss")
+		}
+	})
+
+	t.Run("invalidate removes the entry", func(t *testing.T) {
+		c := &lifecycleConfigurationCache{entries: make(map[string]lifecycleConfigurationCacheEntry)}
+
+		c.put("", "bucket", "", false, output)
+		c.invalidate("", "bucket", "")
+
+		if _, ok := c.get("", "bucket", "", false); ok {
+			t.Fatal("expected a miss after invalidate")
+		}
+	})
+
+	t.Run("expired entries miss", func(t *testing.T) {
+		c := &lifecycleConfigurationCache{entries: make(map[string]lifecycleConfigurationCacheEntry)}
+
+		key := lifecycleConfigurationCacheKey("", "bucket", "")
+		c.entries[key] = lifecycleConfigurationCacheEntry{
+			output:    output,
+			expiresAt: time.Now().Add(-time.Second),
+		}
+
+		if _, ok := c.get("", "bucket", "", false); ok {
+			t.Fatal("expected an expired entry to miss")
+		}
+	})
+}